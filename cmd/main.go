@@ -2,16 +2,24 @@ package main
 
 import (
 	"context"
+	"database/sql"
+	"fmt"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
 	"syscall"
 	"task_api/internal/handler"
 	"task_api/internal/logger"
 	"task_api/internal/repository"
 	"task_api/internal/service"
+	"task_api/internal/task"
 	"time"
+
+	_ "github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
 )
 
 type Config struct {
@@ -23,10 +31,105 @@ func loadConfig() Config {
 		Logger: logger.Config{
 			Level:        logger.ParseLevel(os.Getenv("LOG_LEVEL")),
 			IsProduction: os.Getenv("APP_ENV") == "production",
+			Sinks:        loadSinkConfigs(),
+			Encoder:      logger.EncoderType(os.Getenv("LOG_ENCODER")),
 		},
 	}
 }
 
+// loadSinkConfigs builds the logger's Sinks from LOG_SINKS, a comma
+// separated list of "stdout", "file", and "http". Each type reads its own
+// options from dedicated env vars; LOG_SINKS defaults to "stdout" when
+// unset.
+func loadSinkConfigs() []logger.SinkConfig {
+	raw := os.Getenv("LOG_SINKS")
+	if raw == "" {
+		raw = "stdout"
+	}
+
+	var sinks []logger.SinkConfig
+	for _, name := range strings.Split(raw, ",") {
+		switch strings.TrimSpace(name) {
+		case "stdout":
+			sinks = append(sinks, logger.SinkConfig{Type: logger.SinkTypeStdout})
+		case "file":
+			sinks = append(sinks, logger.SinkConfig{
+				Type:       logger.SinkTypeFile,
+				Filename:   os.Getenv("LOG_FILE_PATH"),
+				MaxSize:    parseInt64Env("LOG_FILE_MAX_SIZE", 0),
+				MaxAge:     parseDurationEnv("LOG_FILE_MAX_AGE", 0),
+				MaxBackups: int(parseInt64Env("LOG_FILE_MAX_BACKUPS", 0)),
+				Compress:   os.Getenv("LOG_FILE_COMPRESS") == "true",
+			})
+		case "http":
+			sinks = append(sinks, logger.SinkConfig{
+				Type: logger.SinkTypeHTTP,
+				URL:  os.Getenv("LOG_HTTP_URL"),
+			})
+		}
+	}
+	return sinks
+}
+
+func parseInt64Env(key string, defaultValue int64) int64 {
+	val, err := strconv.ParseInt(os.Getenv(key), 10, 64)
+	if err != nil {
+		return defaultValue
+	}
+	return val
+}
+
+func parseDurationEnv(key string, defaultValue time.Duration) time.Duration {
+	val, err := time.ParseDuration(os.Getenv(key))
+	if err != nil {
+		return defaultValue
+	}
+	return val
+}
+
+// loadSampler builds the Sampler RequestLogger uses for traces with no
+// incoming traceparent, from TRACE_SAMPLE_RATIO (a float between 0 and 1).
+// It defaults to logger.AlwaysOn when unset or invalid.
+func loadSampler() logger.Sampler {
+	raw := os.Getenv("TRACE_SAMPLE_RATIO")
+	if raw == "" {
+		return logger.AlwaysOn
+	}
+	ratio, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return logger.AlwaysOn
+	}
+	return logger.TraceIDRatio(ratio)
+}
+
+// newTaskRepository wires up the in-memory repository.TaskRepository when
+// DATABASE_URL is unset, or a migrated repository.SQLTaskRepository
+// against it otherwise. The returned close func must be deferred by the
+// caller.
+func newTaskRepository(ctx context.Context, log logger.Logger) (task.Repository, func(), error) {
+	dsn := os.Getenv("DATABASE_URL")
+	if dsn == "" {
+		return repository.NewTaskRepository(log), func() {}, nil
+	}
+
+	driverName, dialect := "sqlite3", repository.DialectSQLite
+	if strings.HasPrefix(dsn, "postgres://") || strings.HasPrefix(dsn, "postgresql://") {
+		driverName, dialect = "postgres", repository.DialectPostgres
+	}
+
+	db, err := sql.Open(driverName, dsn)
+	if err != nil {
+		return nil, nil, fmt.Errorf("opening %s database: %w", driverName, err)
+	}
+
+	if err := repository.RunMigrations(ctx, db, dialect); err != nil {
+		db.Close()
+		return nil, nil, err
+	}
+
+	return repository.NewSQLTaskRepository(db, dialect, log), func() { db.Close() }, nil
+}
+
 func main() {
 	cfg := loadConfig()
 	appCtx, cancel := context.WithCancel(context.Background())
@@ -35,14 +138,19 @@ func main() {
 	baseLogger := logger.NewAsyncLogger(appCtx, cfg.Logger)
 	baseLogger.Info("Application starting...")
 
-	taskRepo := repository.NewTaskRepository(baseLogger)
+	taskRepo, closeRepo, err := newTaskRepository(appCtx, baseLogger)
+	if err != nil {
+		baseLogger.Fatal("Failed to initialize repository", "error", err)
+	}
+	defer closeRepo()
+
 	taskService := service.NewTaskService(taskRepo, baseLogger)
 	taskHandler := handler.NewTaskHandler(taskService, baseLogger)
 
 	mux := http.NewServeMux()
 	taskHandler.RegisterRoutes(mux)
 
-	loggedMux := logger.RequestLogger(baseLogger)(mux)
+	loggedMux := logger.RequestLogger(baseLogger, logger.WithSampler(loadSampler()))(mux)
 
 	server := &http.Server{Addr: ":8080", Handler: loggedMux}
 
@@ -3,6 +3,7 @@ package handler
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
@@ -138,5 +139,8 @@ func parseIntQueryParam(r *http.Request, key string, defaultValue int) (int, err
 	if err != nil {
 		return 0, err
 	}
+	if val < 0 {
+		return 0, fmt.Errorf("%s must not be negative", key)
+	}
 	return val, nil
 }
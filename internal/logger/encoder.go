@@ -0,0 +1,208 @@
+package logger
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Record is the fully-resolved data for a single log line, ready to be
+// rendered by an Encoder.
+type Record struct {
+	Time    time.Time
+	Level   string
+	Message string
+	Fields  []interface{}
+}
+
+// Encoder renders a Record into the bytes an asyncLogger hands to its Sink.
+type Encoder interface {
+	Encode(r Record) []byte
+}
+
+// FramedEncoder marks an Encoder whose Encode output must reach the Sink
+// through its own, individual Write call rather than being concatenated
+// with other records — for example one complete OTLP envelope per HTTP
+// POST. Encoders that don't implement it (Text, JSON) produce
+// self-delimited lines that are safe to batch together.
+type FramedEncoder interface {
+	Encoder
+	Framed() bool
+}
+
+// TextEncoder renders the module's original `key=value` line format.
+type TextEncoder struct{}
+
+func (TextEncoder) Encode(r Record) []byte {
+	var sb strings.Builder
+	sb.WriteString(r.Time.Format(time.RFC3339Nano))
+	sb.WriteString(" ")
+	sb.WriteString(r.Level)
+	sb.WriteString(" ")
+	sb.WriteString(r.Message)
+
+	for i := 0; i < len(r.Fields); i += 2 {
+		sb.WriteString(" ")
+		key, ok := r.Fields[i].(string)
+		if !ok {
+			continue
+		}
+		sb.WriteString(key)
+		sb.WriteString("=")
+		if i+1 < len(r.Fields) {
+			appendValue(&sb, r.Fields[i+1])
+		}
+	}
+	sb.WriteString("\n")
+	return []byte(sb.String())
+}
+
+// JSONEncoder renders one JSON object per line with reserved "ts", "level",
+// "msg", "trace_id", and "span_id" fields, plus the record's structured
+// fields as typed JSON values. It is selected automatically when
+// Config.IsProduction is true.
+type JSONEncoder struct {
+	pool sync.Pool
+}
+
+func NewJSONEncoder() *JSONEncoder {
+	return &JSONEncoder{pool: sync.Pool{New: func() interface{} { return new(bytes.Buffer) }}}
+}
+
+func (e *JSONEncoder) Encode(r Record) []byte {
+	buf := bufFromPool(&e.pool)
+	buf.Reset()
+	defer e.pool.Put(buf)
+
+	traceID, spanID, rest := extractReserved(r.Fields)
+
+	buf.WriteByte('{')
+	buf.WriteString(`"ts":`)
+	writeJSONString(buf, r.Time.Format(time.RFC3339Nano))
+	buf.WriteString(`,"level":`)
+	writeJSONString(buf, r.Level)
+	buf.WriteString(`,"msg":`)
+	writeJSONString(buf, r.Message)
+	if traceID != "" {
+		buf.WriteString(`,"trace_id":`)
+		writeJSONString(buf, traceID)
+	}
+	if spanID != "" {
+		buf.WriteString(`,"span_id":`)
+		writeJSONString(buf, spanID)
+	}
+
+	for i := 0; i < len(rest); i += 2 {
+		key, ok := rest[i].(string)
+		if !ok || i+1 >= len(rest) {
+			continue
+		}
+		buf.WriteByte(',')
+		writeJSONString(buf, key)
+		buf.WriteByte(':')
+		writeJSONValue(buf, rest[i+1])
+	}
+	buf.WriteString("}\n")
+
+	out := make([]byte, buf.Len())
+	copy(out, buf.Bytes())
+	return out
+}
+
+// bufFromPool returns a *bytes.Buffer from pool, tolerating the zero value
+// of sync.Pool (no New func, so Get returns nil) that a directly
+// constructed JSONEncoder{}/OTLPEncoder{} would have instead of going
+// through NewJSONEncoder/NewOTLPEncoder.
+func bufFromPool(pool *sync.Pool) *bytes.Buffer {
+	if buf, ok := pool.Get().(*bytes.Buffer); ok {
+		return buf
+	}
+	return new(bytes.Buffer)
+}
+
+// extractReserved pulls the "trace_id"/"span_id" pairs out of fields, so
+// JSONEncoder and OTLPEncoder can promote them to dedicated record fields
+// instead of emitting them twice.
+func extractReserved(fields []interface{}) (traceID, spanID string, rest []interface{}) {
+	rest = make([]interface{}, 0, len(fields))
+	for i := 0; i < len(fields); i += 2 {
+		key, ok := fields[i].(string)
+		if ok && i+1 < len(fields) {
+			if key == "trace_id" {
+				if v, ok := fields[i+1].(string); ok {
+					traceID = v
+					continue
+				}
+			}
+			if key == "span_id" {
+				if v, ok := fields[i+1].(string); ok {
+					spanID = v
+					continue
+				}
+			}
+		}
+		rest = append(rest, fields[i])
+		if i+1 < len(fields) {
+			rest = append(rest, fields[i+1])
+		}
+	}
+	return traceID, spanID, rest
+}
+
+func writeJSONValue(buf *bytes.Buffer, v interface{}) {
+	switch val := v.(type) {
+	case string:
+		writeJSONString(buf, val)
+	case int:
+		buf.WriteString(strconv.Itoa(val))
+	case int64:
+		buf.WriteString(strconv.FormatInt(val, 10))
+	case uint:
+		buf.WriteString(strconv.FormatUint(uint64(val), 10))
+	case uint64:
+		buf.WriteString(strconv.FormatUint(val, 10))
+	case float64:
+		buf.WriteString(strconv.FormatFloat(val, 'f', -1, 64))
+	case bool:
+		buf.WriteString(strconv.FormatBool(val))
+	case error:
+		writeJSONString(buf, val.Error())
+	default:
+		writeJSONString(buf, fmt.Sprintf("%v", val))
+	}
+}
+
+const hexDigits = "0123456789abcdef"
+
+// writeJSONString quotes and escapes s directly into buf, per the JSON
+// string grammar, avoiding the per-call allocation json.Marshal(s) would
+// incur for every key and string value an Encoder writes.
+func writeJSONString(buf *bytes.Buffer, s string) {
+	buf.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			buf.WriteString(`\"`)
+		case '\\':
+			buf.WriteString(`\\`)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case '\t':
+			buf.WriteString(`\t`)
+		default:
+			if r < 0x20 {
+				buf.WriteString(`\u00`)
+				buf.WriteByte(hexDigits[r>>4])
+				buf.WriteByte(hexDigits[r&0xf])
+				continue
+			}
+			buf.WriteRune(r)
+		}
+	}
+	buf.WriteByte('"')
+}
@@ -0,0 +1,53 @@
+package logger
+
+import (
+	"testing"
+	"time"
+)
+
+func benchRecord() Record {
+	return Record{
+		Time:    time.Unix(1700000000, 0),
+		Level:   "INFO",
+		Message: "request completed",
+		Fields:  []interface{}{"trace_id", "abc123", "method", "GET", "status", 200, "duration_ms", 12.5},
+	}
+}
+
+func BenchmarkTextEncoder_Encode(b *testing.B) {
+	enc := TextEncoder{}
+	r := benchRecord()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		enc.Encode(r)
+	}
+}
+
+func BenchmarkJSONEncoder_Encode(b *testing.B) {
+	enc := NewJSONEncoder()
+	r := benchRecord()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		enc.Encode(r)
+	}
+}
+
+// TestJSONEncoder_Encode_AllocsNoWorseThanText guards the "no extra
+// allocations beyond the text path" requirement: json.Marshal-per-string-field
+// regressed this to 2.4x TextEncoder's allocs once before, with nothing
+// catching it since the benchmarks above only report numbers, they don't
+// assert on them.
+func TestJSONEncoder_Encode_AllocsNoWorseThanText(t *testing.T) {
+	r := benchRecord()
+
+	textEnc := TextEncoder{}
+	textAllocs := testing.AllocsPerRun(100, func() { textEnc.Encode(r) })
+
+	jsonEnc := NewJSONEncoder()
+	jsonAllocs := testing.AllocsPerRun(100, func() { jsonEnc.Encode(r) })
+
+	if jsonAllocs > textAllocs {
+		t.Fatalf("JSONEncoder.Encode allocated %.0f times vs TextEncoder.Encode's %.0f; want no more than %.0f",
+			jsonAllocs, textAllocs, textAllocs)
+	}
+}
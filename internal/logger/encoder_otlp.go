@@ -0,0 +1,109 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// OTLPEncoder renders each Record as a complete OTLP logs
+// ExportLogsServiceRequest
+// (https://github.com/open-telemetry/opentelemetry-proto/blob/main/opentelemetry/proto/collector/logs/v1/logs_service.proto),
+// using OTLP/HTTP's JSON encoding since this module carries no protobuf
+// dependency. Each record is its own single-logRecord batch, and
+// OTLPEncoder implements FramedEncoder so asyncLogger delivers every
+// envelope to the Sink in its own Write call instead of concatenating it
+// with others — a collector's /v1/logs endpoint expects exactly one
+// ExportLogsServiceRequest body per HTTP call. Pair it with an HTTPSink
+// pointed at that endpoint.
+type OTLPEncoder struct {
+	pool sync.Pool
+}
+
+func NewOTLPEncoder() *OTLPEncoder {
+	return &OTLPEncoder{pool: sync.Pool{New: func() interface{} { return new(bytes.Buffer) }}}
+}
+
+func (e *OTLPEncoder) Framed() bool { return true }
+
+type otlpAnyValue struct {
+	StringValue string `json:"stringValue"`
+}
+
+type otlpKeyValue struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+type otlpLogRecord struct {
+	TimeUnixNano uint64         `json:"timeUnixNano,string"`
+	SeverityText string         `json:"severityText"`
+	Body         otlpAnyValue   `json:"body"`
+	Attributes   []otlpKeyValue `json:"attributes,omitempty"`
+	TraceID      string         `json:"traceId,omitempty"`
+	SpanID       string         `json:"spanId,omitempty"`
+}
+
+type otlpScopeLogs struct {
+	LogRecords []otlpLogRecord `json:"logRecords"`
+}
+
+type otlpResourceLogs struct {
+	ScopeLogs []otlpScopeLogs `json:"scopeLogs"`
+}
+
+type otlpExportLogsServiceRequest struct {
+	ResourceLogs []otlpResourceLogs `json:"resourceLogs"`
+}
+
+func (e *OTLPEncoder) Encode(r Record) []byte {
+	traceID, spanID, rest := extractReserved(r.Fields)
+
+	record := otlpLogRecord{
+		TimeUnixNano: uint64(r.Time.UnixNano()),
+		SeverityText: r.Level,
+		Body:         otlpAnyValue{StringValue: r.Message},
+		TraceID:      traceID,
+		SpanID:       spanID,
+	}
+	for i := 0; i < len(rest); i += 2 {
+		key, ok := rest[i].(string)
+		if !ok || i+1 >= len(rest) {
+			continue
+		}
+		record.Attributes = append(record.Attributes, otlpKeyValue{
+			Key:   key,
+			Value: otlpAnyValue{StringValue: otlpStringify(rest[i+1])},
+		})
+	}
+
+	request := otlpExportLogsServiceRequest{
+		ResourceLogs: []otlpResourceLogs{
+			{ScopeLogs: []otlpScopeLogs{{LogRecords: []otlpLogRecord{record}}}},
+		},
+	}
+
+	buf := bufFromPool(&e.pool)
+	buf.Reset()
+	defer e.pool.Put(buf)
+
+	if err := json.NewEncoder(buf).Encode(request); err != nil {
+		return nil
+	}
+
+	out := make([]byte, buf.Len())
+	copy(out, buf.Bytes())
+	return out
+}
+
+func otlpStringify(v interface{}) string {
+	switch val := v.(type) {
+	case string:
+		return val
+	case error:
+		return val.Error()
+	default:
+		return fmt.Sprintf("%v", val)
+	}
+}
@@ -0,0 +1,24 @@
+package logger
+
+import "testing"
+
+// TestJSONEncoder_ZeroValue and TestOTLPEncoder_ZeroValue guard against a
+// directly constructed logger.JSONEncoder{}/logger.OTLPEncoder{} (rather
+// than NewJSONEncoder()/NewOTLPEncoder()) panicking: their zero-value
+// sync.Pool has no New func, so Get() returns nil instead of a
+// *bytes.Buffer.
+func TestJSONEncoder_ZeroValue(t *testing.T) {
+	enc := JSONEncoder{}
+	out := enc.Encode(benchRecord())
+	if len(out) == 0 {
+		t.Fatal("Encode on zero-value JSONEncoder returned no output")
+	}
+}
+
+func TestOTLPEncoder_ZeroValue(t *testing.T) {
+	enc := OTLPEncoder{}
+	out := enc.Encode(benchRecord())
+	if len(out) == 0 {
+		t.Fatal("Encode on zero-value OTLPEncoder returned no output")
+	}
+}
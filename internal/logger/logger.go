@@ -4,14 +4,13 @@ import (
 	"bytes"
 	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"math/rand"
 	"net/http"
 	"os"
 	"strconv"
 	"strings"
 	"sync"
-	"sync/atomic"
 	"time"
 )
 
@@ -27,6 +26,9 @@ func FromContext(ctx context.Context) Logger {
 	if l, ok := ctx.Value(loggerKey).(Logger); ok && l != nil {
 		return l
 	}
+	if sl, ok := ctx.Value(slogContextKey{}).(*slog.Logger); ok && sl != nil {
+		return FromSlog(sl)
+	}
 
 	return NewNoOpLogger()
 }
@@ -51,8 +53,20 @@ const (
 type Config struct {
 	Level        LogLevel
 	IsProduction bool
+	Sinks        []SinkConfig
+	Encoder      EncoderType
 }
 
+// EncoderType selects the Encoder NewAsyncLogger builds when no WithEncoder
+// option overrides it. The zero value defers to Config.IsProduction.
+type EncoderType string
+
+const (
+	EncoderTypeText EncoderType = "text"
+	EncoderTypeJSON EncoderType = "json"
+	EncoderTypeOTLP EncoderType = "otlp"
+)
+
 type Logger interface {
 	Debug(msg string, fields ...interface{})
 	Info(msg string, fields ...interface{})
@@ -69,10 +83,13 @@ type asyncLogger struct {
 	cfg           Config
 	logChan       chan []byte
 	wg            sync.WaitGroup
-	writer        *log.Logger
+	sink          Sink
+	encoder       Encoder
+	framed        bool
 	bufferSize    int
 	flushInterval time.Duration
 	contextFields []interface{}
+	cancel        context.CancelFunc
 }
 
 type Option func(*asyncLogger)
@@ -93,22 +110,49 @@ func WithFlushInterval(interval time.Duration) Option {
 	}
 }
 
+// WithSink overrides the Sink built from Config.Sinks.
+func WithSink(sink Sink) Option {
+	return func(l *asyncLogger) {
+		if sink != nil {
+			l.sink = sink
+		}
+	}
+}
+
+// WithEncoder overrides the Encoder Config.IsProduction would otherwise
+// select.
+func WithEncoder(encoder Encoder) Option {
+	return func(l *asyncLogger) {
+		if encoder != nil {
+			l.encoder = encoder
+		}
+	}
+}
+
 func NewAsyncLogger(ctx context.Context, cfg Config, opts ...Option) Logger {
+	runCtx, cancel := context.WithCancel(ctx)
+
 	l := &asyncLogger{
 		cfg:           cfg,
-		writer:        log.New(os.Stdout, "", 0),
+		sink:          buildSink(cfg),
+		encoder:       defaultEncoder(cfg),
 		bufferSize:    defaultBufferSize,
 		flushInterval: defaultFlushInterval,
+		cancel:        cancel,
 	}
 
 	for _, opt := range opts {
 		opt(l)
 	}
 
+	if fe, ok := l.encoder.(FramedEncoder); ok {
+		l.framed = fe.Framed()
+	}
+
 	l.logChan = make(chan []byte, l.bufferSize+1)
 
 	l.wg.Add(1)
-	go l.run(ctx)
+	go l.run(runCtx)
 
 	return l
 }
@@ -121,10 +165,24 @@ func (l *asyncLogger) run(ctx context.Context) {
 	var batch bytes.Buffer
 	flush := func() {
 		if batch.Len() > 0 {
-			l.writer.Writer().Write(batch.Bytes())
+			l.sink.Write(batch.Bytes())
 			batch.Reset()
 		}
 	}
+	// write delivers msg to the sink. Framed encoders (e.g. OTLP) need
+	// each message to arrive as its own Write call, so those bypass the
+	// batch buffer entirely instead of being concatenated into it.
+	write := func(msg []byte) {
+		if l.framed {
+			flush()
+			l.sink.Write(msg)
+			return
+		}
+		batch.Write(msg)
+		if batch.Len() >= l.bufferSize {
+			flush()
+		}
+	}
 
 	for {
 		select {
@@ -132,25 +190,25 @@ func (l *asyncLogger) run(ctx context.Context) {
 			for {
 				select {
 				case msg := <-l.logChan:
-					batch.Write(msg)
+					write(msg)
 				default:
 					flush()
+					l.sink.Flush()
 					return
 				}
 			}
 
 		case <-ticker.C:
 			flush()
+			l.sink.Flush()
 
 		case msg, ok := <-l.logChan:
 			if !ok {
 				flush()
+				l.sink.Flush()
 				return
 			}
-			batch.Write(msg)
-			if batch.Len() >= l.bufferSize {
-				flush()
-			}
+			write(msg)
 		}
 	}
 }
@@ -212,41 +270,49 @@ func (l *asyncLogger) With(fields ...interface{}) Logger {
 }
 
 func (l *asyncLogger) log(level, msg string, fields ...interface{}) {
-	var sb strings.Builder
-	sb.WriteString(time.Now().Format(time.RFC3339Nano))
-	sb.WriteString(" ")
-	sb.WriteString(level)
-	sb.WriteString(" ")
-	sb.WriteString(msg)
-
-	allFields := append(l.contextFields, fields...)
-
-	if len(allFields) > 0 {
-		for i := 0; i < len(allFields); i += 2 {
-			sb.WriteString(" ")
-			key, ok := allFields[i].(string)
-			if !ok {
-				continue
-			}
-			sb.WriteString(key)
-			sb.WriteString("=")
-			if i+1 < len(allFields) {
-				appendValue(&sb, allFields[i+1])
-			}
-		}
-	}
-	sb.WriteString("\n")
+	allFields := make([]interface{}, 0, len(l.contextFields)+len(fields))
+	allFields = append(allFields, l.contextFields...)
+	allFields = append(allFields, fields...)
+
+	encoded := l.encoder.Encode(Record{
+		Time:    time.Now(),
+		Level:   level,
+		Message: msg,
+		Fields:  allFields,
+	})
 
 	select {
-	case l.logChan <- []byte(sb.String()):
+	case l.logChan <- encoded:
 	default:
-		fmt.Fprintf(os.Stderr, "WARNING: Logger channel is full. Log message dropped: %s\n", sb.String())
+		fmt.Fprintf(os.Stderr, "WARNING: Logger channel is full. Log message dropped: %s", encoded)
 	}
+}
 
+// defaultEncoder honors an explicit Config.Encoder, falling back to
+// JSONEncoder in production and TextEncoder otherwise; use WithEncoder to
+// override it outright.
+func defaultEncoder(cfg Config) Encoder {
+	switch cfg.Encoder {
+	case EncoderTypeJSON:
+		return NewJSONEncoder()
+	case EncoderTypeOTLP:
+		return NewOTLPEncoder()
+	case EncoderTypeText:
+		return TextEncoder{}
+	}
+
+	if cfg.IsProduction {
+		return NewJSONEncoder()
+	}
+	return TextEncoder{}
 }
 
 func (l *asyncLogger) Close() {
+	l.cancel()
 	l.wg.Wait()
+	if err := l.sink.Close(); err != nil {
+		fmt.Fprintf(os.Stderr, "WARNING: error closing log sink: %v\n", err)
+	}
 }
 
 func appendValue(sb *strings.Builder, value interface{}) {
@@ -313,16 +379,40 @@ func NewNoOpLogger() Logger {
 
 }
 
-func RequestLogger(baseLogger Logger) func(http.Handler) http.Handler {
+type requestLoggerOptions struct {
+	sampler Sampler
+}
+
+// RequestLoggerOption configures the middleware returned by RequestLogger.
+type RequestLoggerOption func(*requestLoggerOptions)
+
+// WithSampler sets the Sampler used to decide new traces. It has no effect
+// on traces that arrive with a valid incoming traceparent header, since
+// those carry their sampled bit forward instead.
+func WithSampler(s Sampler) RequestLoggerOption {
+	return func(o *requestLoggerOptions) {
+		if s != nil {
+			o.sampler = s
+		}
+	}
+}
+
+func RequestLogger(baseLogger Logger, opts ...RequestLoggerOption) func(http.Handler) http.Handler {
+	options := requestLoggerOptions{sampler: AlwaysOn}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			traceparent := generateTraceparent()
-			reqLogger := baseLogger.With("traceparent", traceparent)
+			sc := extractSpanContext(r, options.sampler)
+			reqLogger := baseLogger.With("trace_id", sc.TraceID(), "span_id", sc.SpanID())
 
 			reqLogger.Info("request started", "method", r.Method, "path", r.URL.Path)
 			start := time.Now()
 
-			ctx := NewContext(r.Context(), reqLogger)
+			ctx := NewSpanContext(r.Context(), sc)
+			ctx = NewContext(ctx, reqLogger)
 			next.ServeHTTP(w, r.WithContext(ctx))
 
 			reqLogger.Info("request completed", "duration", time.Since(start))
@@ -330,35 +420,35 @@ func RequestLogger(baseLogger Logger) func(http.Handler) http.Handler {
 	}
 }
 
-var requests = atomic.Int64{}
+// extractSpanContext parses an incoming traceparent header, falling back to
+// a freshly sampled SpanContext when it is absent or invalid.
+func extractSpanContext(r *http.Request, sampler Sampler) SpanContext {
+	if header := r.Header.Get(traceparentHeader); header != "" {
+		if traceID, _, sampled, ok := parseTraceparent(header); ok {
+			return SpanContext{
+				traceID:    traceID,
+				spanID:     generateSpanID(),
+				sampled:    sampled,
+				tracestate: r.Header.Get(tracestateHeader),
+			}
+		}
+	}
+
+	traceID := generateTraceID()
+	return SpanContext{
+		traceID: traceID,
+		spanID:  generateSpanID(),
+		sampled: sampler.ShouldSample(traceID),
+	}
+}
 
 func generateTraceID() string {
 	hi := rand.Uint64()
 	lo := rand.Uint64()
 	return fmt.Sprintf("%016x%016x", hi, lo)
 }
+
 func generateSpanID() string {
 	randomNum := rand.Uint64()
 	return fmt.Sprintf("%016x", randomNum)
 }
-
-func generateTraceFlags() string {
-	defer func() {
-		requests.Add(1)
-	}()
-
-	if requests.Load()%100 == 0 {
-		return "01"
-	}
-
-	return "00"
-}
-
-func generateTraceparent() string {
-	version := "00"
-	traceID := generateTraceID()
-	spanID := generateSpanID()
-	traceFlags := generateTraceFlags()
-
-	return fmt.Sprintf("%s-%s-%s-%s", version, traceID, spanID, traceFlags)
-}
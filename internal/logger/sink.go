@@ -0,0 +1,128 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// Sink is the destination a Logger writes its batched, already-formatted
+// log lines to.
+type Sink interface {
+	Write(p []byte) (int, error)
+	Flush() error
+	Close() error
+}
+
+// SinkType selects which Sink implementation a SinkConfig builds.
+type SinkType string
+
+const (
+	SinkTypeStdout SinkType = "stdout"
+	SinkTypeFile   SinkType = "file"
+	SinkTypeHTTP   SinkType = "http"
+)
+
+// SinkConfig describes one configured Sink. Only the fields relevant to
+// Type need to be set.
+type SinkConfig struct {
+	Type SinkType
+
+	// File sink options.
+	Filename   string
+	MaxSize    int64
+	MaxAge     time.Duration
+	MaxBackups int
+	Compress   bool
+
+	// HTTP sink options.
+	URL string
+}
+
+// StdoutSink writes directly to os.Stdout. It is the default Sink when no
+// Config.Sinks are configured.
+type StdoutSink struct{}
+
+func (StdoutSink) Write(p []byte) (int, error) { return os.Stdout.Write(p) }
+func (StdoutSink) Flush() error                { return nil }
+func (StdoutSink) Close() error                { return nil }
+
+// MultiSink fans every Write/Flush/Close out to all of its sinks.
+type MultiSink struct {
+	sinks []Sink
+}
+
+func NewMultiSink(sinks ...Sink) *MultiSink {
+	return &MultiSink{sinks: sinks}
+}
+
+func (m *MultiSink) Write(p []byte) (int, error) {
+	var firstErr error
+	for _, s := range m.sinks {
+		if _, err := s.Write(p); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return len(p), firstErr
+}
+
+func (m *MultiSink) Flush() error {
+	var firstErr error
+	for _, s := range m.sinks {
+		if err := s.Flush(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+func (m *MultiSink) Close() error {
+	var firstErr error
+	for _, s := range m.sinks {
+		if err := s.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// buildSink builds the Sink an asyncLogger writes to from cfg.Sinks,
+// falling back to StdoutSink when none are configured or none could be
+// built.
+func buildSink(cfg Config) Sink {
+	if len(cfg.Sinks) == 0 {
+		return StdoutSink{}
+	}
+
+	sinks := make([]Sink, 0, len(cfg.Sinks))
+	for _, sc := range cfg.Sinks {
+		sink, err := newSinkFromConfig(sc)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "WARNING: skipping sink %q: %v\n", sc.Type, err)
+			continue
+		}
+		sinks = append(sinks, sink)
+	}
+
+	switch len(sinks) {
+	case 0:
+		return StdoutSink{}
+	case 1:
+		return sinks[0]
+	default:
+		return NewMultiSink(sinks...)
+	}
+}
+
+func newSinkFromConfig(sc SinkConfig) (Sink, error) {
+	switch sc.Type {
+	case SinkTypeFile:
+		return NewRotatingFileSink(sc.Filename, sc.MaxSize, sc.MaxAge, sc.MaxBackups, sc.Compress)
+	case SinkTypeHTTP:
+		return NewHTTPSink(sc.URL), nil
+	case SinkTypeStdout, "":
+		return StdoutSink{}, nil
+	default:
+		return nil, fmt.Errorf("unknown sink type %q", sc.Type)
+	}
+}
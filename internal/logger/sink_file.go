@@ -0,0 +1,200 @@
+package logger
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RotatingFileSink writes batches to Filename, rotating to a timestamped
+// backup before any Write that would take the file past MaxSize bytes. On
+// construction, backups older than MaxAge are pruned; after every rotation,
+// backups older than MaxAge or beyond MaxBackups in count are pruned.
+// Compress gzips rotated backups in the background.
+type RotatingFileSink struct {
+	Filename   string
+	MaxSize    int64
+	MaxAge     time.Duration
+	MaxBackups int
+	Compress   bool
+
+	mu   sync.Mutex
+	file *os.File
+	size int64
+}
+
+func NewRotatingFileSink(filename string, maxSize int64, maxAge time.Duration, maxBackups int, compress bool) (*RotatingFileSink, error) {
+	s := &RotatingFileSink{
+		Filename:   filename,
+		MaxSize:    maxSize,
+		MaxAge:     maxAge,
+		MaxBackups: maxBackups,
+		Compress:   compress,
+	}
+	if err := s.openCurrent(); err != nil {
+		return nil, err
+	}
+	s.pruneAged()
+	return s, nil
+}
+
+func (s *RotatingFileSink) openCurrent() error {
+	f, err := os.OpenFile(s.Filename, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("rotating file sink: opening %s: %w", s.Filename, err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("rotating file sink: stat %s: %w", s.Filename, err)
+	}
+	s.file = f
+	s.size = info.Size()
+	return nil
+}
+
+func (s *RotatingFileSink) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.MaxSize > 0 && s.size+int64(len(p)) > s.MaxSize {
+		if err := s.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := s.file.Write(p)
+	s.size += int64(n)
+	return n, err
+}
+
+func (s *RotatingFileSink) rotate() error {
+	if err := s.file.Close(); err != nil {
+		return fmt.Errorf("rotating file sink: closing %s: %w", s.Filename, err)
+	}
+
+	backupName := fmt.Sprintf("%s.%s", s.Filename, time.Now().Format("20060102T150405.000000000"))
+	if err := os.Rename(s.Filename, backupName); err != nil {
+		return fmt.Errorf("rotating file sink: renaming %s: %w", s.Filename, err)
+	}
+
+	if s.Compress {
+		go compressBackup(backupName)
+	}
+
+	if err := s.openCurrent(); err != nil {
+		return err
+	}
+	s.pruneBackups()
+	return nil
+}
+
+func compressBackup(name string) {
+	src, err := os.Open(name)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "WARNING: rotating file sink: compressing %s: %v\n", name, err)
+		return
+	}
+	defer src.Close()
+
+	dst, err := os.Create(name + ".gz")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "WARNING: rotating file sink: compressing %s: %v\n", name, err)
+		return
+	}
+	defer dst.Close()
+
+	gw := gzip.NewWriter(dst)
+	if _, err := io.Copy(gw, src); err != nil {
+		fmt.Fprintf(os.Stderr, "WARNING: rotating file sink: compressing %s: %v\n", name, err)
+		gw.Close()
+		return
+	}
+	if err := gw.Close(); err != nil {
+		fmt.Fprintf(os.Stderr, "WARNING: rotating file sink: compressing %s: %v\n", name, err)
+		return
+	}
+	os.Remove(name)
+}
+
+type backupFile struct {
+	path    string
+	modTime time.Time
+}
+
+func (s *RotatingFileSink) listBackups() []backupFile {
+	dir := filepath.Dir(s.Filename)
+	base := filepath.Base(s.Filename)
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+
+	var backups []backupFile
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasPrefix(e.Name(), base+".") {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		backups = append(backups, backupFile{path: filepath.Join(dir, e.Name()), modTime: info.ModTime()})
+	}
+	return backups
+}
+
+// pruneAged removes backups left over from a previous process run that
+// have already aged past MaxAge.
+func (s *RotatingFileSink) pruneAged() {
+	if s.MaxAge <= 0 {
+		return
+	}
+	for _, b := range s.listBackups() {
+		if time.Since(b.modTime) > s.MaxAge {
+			os.Remove(b.path)
+		}
+	}
+}
+
+func (s *RotatingFileSink) pruneBackups() {
+	backups := s.listBackups()
+
+	if s.MaxAge > 0 {
+		kept := backups[:0]
+		for _, b := range backups {
+			if time.Since(b.modTime) > s.MaxAge {
+				os.Remove(b.path)
+				continue
+			}
+			kept = append(kept, b)
+		}
+		backups = kept
+	}
+
+	if s.MaxBackups > 0 && len(backups) > s.MaxBackups {
+		sort.Slice(backups, func(i, j int) bool { return backups[i].modTime.Before(backups[j].modTime) })
+		for _, b := range backups[:len(backups)-s.MaxBackups] {
+			os.Remove(b.path)
+		}
+	}
+}
+
+func (s *RotatingFileSink) Flush() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.file.Sync()
+}
+
+func (s *RotatingFileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.file.Close()
+}
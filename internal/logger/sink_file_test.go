@@ -0,0 +1,113 @@
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRotatingFileSink_RotatesAtMaxSize(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+
+	s, err := NewRotatingFileSink(path, 10, 0, 0, false)
+	if err != nil {
+		t.Fatalf("NewRotatingFileSink: %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+
+	if _, err := s.Write([]byte("0123456789")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	// This Write pushes size past MaxSize, so it must rotate first.
+	if _, err := s.Write([]byte("next")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	if got := readFile(t, path); got != "next" {
+		t.Errorf("current file = %q, want %q", got, "next")
+	}
+
+	backups := listBackupNames(t, path)
+	if len(backups) != 1 {
+		t.Fatalf("got %d backups, want 1: %v", len(backups), backups)
+	}
+	if got := readFile(t, backups[0]); got != "0123456789" {
+		t.Errorf("backup contents = %q, want %q", got, "0123456789")
+	}
+}
+
+func TestRotatingFileSink_PrunesByMaxBackups(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+
+	s, err := NewRotatingFileSink(path, 1, 0, 2, false)
+	if err != nil {
+		t.Fatalf("NewRotatingFileSink: %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+
+	for i := 0; i < 5; i++ {
+		if _, err := s.Write([]byte("x")); err != nil {
+			t.Fatalf("Write %d: %v", i, err)
+		}
+		time.Sleep(2 * time.Millisecond) // backup filenames carry a timestamp; keep them distinct
+	}
+
+	backups := listBackupNames(t, path)
+	if len(backups) != 2 {
+		t.Fatalf("got %d backups, want MaxBackups=2: %v", len(backups), backups)
+	}
+}
+
+func TestRotatingFileSink_PrunesAgedBackupsOnStartup(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	stale := path + ".20000101T000000.000000000"
+	if err := os.WriteFile(stale, []byte("old"), 0644); err != nil {
+		t.Fatalf("writing stale backup: %v", err)
+	}
+	old := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(stale, old, old); err != nil {
+		t.Fatalf("Chtimes: %v", err)
+	}
+
+	s, err := NewRotatingFileSink(path, 0, time.Minute, 0, false)
+	if err != nil {
+		t.Fatalf("NewRotatingFileSink: %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+
+	if _, err := os.Stat(stale); !os.IsNotExist(err) {
+		t.Errorf("expected stale backup %s to be pruned on startup, stat err = %v", stale, err)
+	}
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading %s: %v", path, err)
+	}
+	return string(b)
+}
+
+func listBackupNames(t *testing.T, path string) []string {
+	t.Helper()
+	dir := filepath.Dir(path)
+	base := filepath.Base(path)
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir: %v", err)
+	}
+
+	var backups []string
+	for _, e := range entries {
+		if strings.HasPrefix(e.Name(), base+".") {
+			backups = append(backups, filepath.Join(dir, e.Name()))
+		}
+	}
+	return backups
+}
@@ -0,0 +1,106 @@
+package logger
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	defaultHTTPSinkQueueSize = 256
+	defaultHTTPSinkRetries   = 3
+)
+
+// HTTPSink POSTs newline-delimited batches to URL. Batches are queued and
+// sent from a background goroutine so Write never blocks on the network;
+// if the queue is full the batch is dropped, mirroring the asyncLogger's
+// own channel-full behavior. Sends that fail with a transport error or a
+// 5xx response are retried a bounded number of times with a short backoff
+// before being dropped; any other response counts as delivered.
+type HTTPSink struct {
+	url        string
+	client     *http.Client
+	maxRetries int
+
+	queue     chan []byte
+	done      chan struct{}
+	closeOnce sync.Once
+	wg        sync.WaitGroup
+}
+
+func NewHTTPSink(url string) *HTTPSink {
+	s := &HTTPSink{
+		url:        url,
+		client:     &http.Client{Timeout: 5 * time.Second},
+		maxRetries: defaultHTTPSinkRetries,
+		queue:      make(chan []byte, defaultHTTPSinkQueueSize),
+		done:       make(chan struct{}),
+	}
+
+	s.wg.Add(1)
+	go s.run()
+
+	return s
+}
+
+func (s *HTTPSink) run() {
+	defer s.wg.Done()
+	for {
+		select {
+		case batch, ok := <-s.queue:
+			if !ok {
+				return
+			}
+			s.send(batch)
+		case <-s.done:
+			for {
+				select {
+				case batch := <-s.queue:
+					s.send(batch)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+func (s *HTTPSink) send(batch []byte) {
+	var err error
+	for attempt := 0; attempt <= s.maxRetries; attempt++ {
+		var resp *http.Response
+		resp, err = s.client.Post(s.url, "application/x-ndjson", bytes.NewReader(batch))
+		if err == nil {
+			resp.Body.Close()
+			if resp.StatusCode < http.StatusInternalServerError {
+				return
+			}
+			err = fmt.Errorf("http sink: server returned %s", resp.Status)
+		}
+		time.Sleep(time.Duration(attempt+1) * 100 * time.Millisecond)
+	}
+	fmt.Fprintf(os.Stderr, "WARNING: HTTP sink giving up after %d retries: %v\n", s.maxRetries, err)
+}
+
+func (s *HTTPSink) Write(p []byte) (int, error) {
+	batch := make([]byte, len(p))
+	copy(batch, p)
+
+	select {
+	case s.queue <- batch:
+	default:
+		fmt.Fprintf(os.Stderr, "WARNING: HTTP sink queue is full. Batch dropped.\n")
+	}
+	return len(p), nil
+}
+
+func (s *HTTPSink) Flush() error { return nil }
+
+func (s *HTTPSink) Close() error {
+	s.closeOnce.Do(func() { close(s.done) })
+	s.wg.Wait()
+	return nil
+}
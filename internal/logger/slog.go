@@ -0,0 +1,144 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"os"
+)
+
+// slogContextKey stores a *slog.Logger in context so handler code that has
+// migrated to slog still benefits from request-scoped fields injected by
+// RequestLogger. log/slog has no such key of its own; NewSlogContext /
+// FromContext's fallback below define the module's own convention for it.
+type slogContextKey struct{}
+
+// NewSlogContext returns a copy of ctx carrying l for FromContext's slog
+// fallback.
+func NewSlogContext(ctx context.Context, l *slog.Logger) context.Context {
+	return context.WithValue(ctx, slogContextKey{}, l)
+}
+
+// slogHandler adapts a Logger to slog.Handler, forwarding records into the
+// Logger's own pipeline instead of re-formatting them.
+type slogHandler struct {
+	logger Logger
+	group  string
+}
+
+// NewSlogHandler returns an slog.Handler that forwards slog.Record
+// attributes into l.
+func NewSlogHandler(l Logger) slog.Handler {
+	return &slogHandler{logger: l}
+}
+
+func (h *slogHandler) Enabled(context.Context, slog.Level) bool {
+	return true
+}
+
+func (h *slogHandler) Handle(_ context.Context, r slog.Record) error {
+	fields := make([]interface{}, 0, r.NumAttrs()*2)
+	r.Attrs(func(a slog.Attr) bool {
+		fields = appendSlogAttr(fields, h.group, a)
+		return true
+	})
+
+	switch mapSlogLevel(r.Level) {
+	case DebugLevel:
+		h.logger.Debug(r.Message, fields...)
+	case WarnLevel:
+		h.logger.Warn(r.Message, fields...)
+	case ErrorLevel:
+		h.logger.Error(r.Message, fields...)
+	default:
+		h.logger.Info(r.Message, fields...)
+	}
+	return nil
+}
+
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	fields := make([]interface{}, 0, len(attrs)*2)
+	for _, a := range attrs {
+		fields = appendSlogAttr(fields, h.group, a)
+	}
+	return &slogHandler{logger: h.logger.With(fields...), group: h.group}
+}
+
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	group := name
+	if h.group != "" {
+		group = h.group + "." + name
+	}
+	return &slogHandler{logger: h.logger, group: group}
+}
+
+// appendSlogAttr flattens a, prefixing its key with group (dotted), and
+// recurses into nested groups so they flatten to dotted keys too.
+func appendSlogAttr(fields []interface{}, group string, a slog.Attr) []interface{} {
+	a.Value = a.Value.Resolve()
+
+	key := a.Key
+	if group != "" {
+		key = group + "." + key
+	}
+
+	if a.Value.Kind() == slog.KindGroup {
+		for _, sub := range a.Value.Group() {
+			fields = appendSlogAttr(fields, key, sub)
+		}
+		return fields
+	}
+
+	return append(fields, key, a.Value.Any())
+}
+
+func mapSlogLevel(level slog.Level) LogLevel {
+	switch {
+	case level < slog.LevelInfo:
+		return DebugLevel
+	case level < slog.LevelWarn:
+		return InfoLevel
+	case level < slog.LevelError:
+		return WarnLevel
+	default:
+		return ErrorLevel
+	}
+}
+
+// slogLogger adapts a *slog.Logger to this module's Logger interface.
+type slogLogger struct {
+	l *slog.Logger
+}
+
+// FromSlog wraps l so callers that hold a *slog.Logger can pass it
+// anywhere a Logger is expected.
+func FromSlog(l *slog.Logger) Logger {
+	return &slogLogger{l: l}
+}
+
+func (s *slogLogger) Debug(msg string, fields ...interface{}) { s.l.Debug(msg, fields...) }
+func (s *slogLogger) Info(msg string, fields ...interface{})  { s.l.Info(msg, fields...) }
+func (s *slogLogger) Warn(msg string, fields ...interface{})  { s.l.Warn(msg, fields...) }
+func (s *slogLogger) Error(msg string, fields ...interface{}) { s.l.Error(msg, fields...) }
+
+func (s *slogLogger) DPanic(msg string, fields ...interface{}) {
+	s.l.Error(msg, fields...)
+}
+
+func (s *slogLogger) Panic(msg string, fields ...interface{}) {
+	s.l.Error(msg, fields...)
+	panic(msg)
+}
+
+func (s *slogLogger) Fatal(msg string, fields ...interface{}) {
+	s.l.Error(msg, fields...)
+	os.Exit(1)
+}
+
+func (s *slogLogger) With(fields ...interface{}) Logger {
+	return &slogLogger{l: s.l.With(fields...)}
+}
+
+func (s *slogLogger) Close() {}
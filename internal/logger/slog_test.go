@@ -0,0 +1,84 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+// captureSink is a Sink that appends every Write to an in-memory buffer,
+// for asserting on the lines an asyncLogger actually produced.
+type captureSink struct {
+	buf bytes.Buffer
+}
+
+func (s *captureSink) Write(p []byte) (int, error) { return s.buf.Write(p) }
+func (s *captureSink) Flush() error                { return nil }
+func (s *captureSink) Close() error                { return nil }
+
+func newCapturingAsyncLogger(t *testing.T) (Logger, *captureSink) {
+	t.Helper()
+	sink := &captureSink{}
+	l := NewAsyncLogger(context.Background(), Config{Level: DebugLevel},
+		WithSink(sink), WithEncoder(NewJSONEncoder()))
+	t.Cleanup(l.Close)
+	return l, sink
+}
+
+func TestNewSlogHandler_ForwardsLevelsAttrsAndGroups(t *testing.T) {
+	l, sink := newCapturingAsyncLogger(t)
+	slogLogger := slog.New(NewSlogHandler(l))
+
+	slogLogger.Warn("disk usage high", "volume", "/data", "percent", 92)
+	slogLogger.WithGroup("request").Info("handled", slog.String("method", "GET"))
+	l.Close()
+
+	lines := strings.Split(strings.TrimSpace(sink.buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d log lines, want 2: %q", len(lines), sink.buf.String())
+	}
+
+	var first map[string]interface{}
+	if err := json.Unmarshal([]byte(lines[0]), &first); err != nil {
+		t.Fatalf("unmarshalling first line: %v", err)
+	}
+	if first["level"] != "WARN" {
+		t.Errorf("got level %v, want WARN", first["level"])
+	}
+	if first["msg"] != "disk usage high" {
+		t.Errorf("got msg %v, want %q", first["msg"], "disk usage high")
+	}
+	if first["volume"] != "/data" {
+		t.Errorf("got volume %v, want /data", first["volume"])
+	}
+	if first["percent"] != float64(92) {
+		t.Errorf("got percent %v, want 92", first["percent"])
+	}
+
+	var second map[string]interface{}
+	if err := json.Unmarshal([]byte(lines[1]), &second); err != nil {
+		t.Fatalf("unmarshalling second line: %v", err)
+	}
+	if second["level"] != "INFO" {
+		t.Errorf("got level %v, want INFO", second["level"])
+	}
+	if second["request.method"] != "GET" {
+		t.Errorf("got request.method %v, want GET (group should flatten to a dotted key)", second["request.method"])
+	}
+}
+
+func TestFromContext_FallsBackToSlogContext(t *testing.T) {
+	l, sink := newCapturingAsyncLogger(t)
+	slogLogger := slog.New(NewSlogHandler(l))
+
+	ctx := NewSlogContext(context.Background(), slogLogger)
+	FromContext(ctx).Info("via slog fallback", "source", "context")
+	l.Close()
+
+	if !strings.Contains(sink.buf.String(), "via slog fallback") {
+		t.Fatalf("expected FromContext's slog fallback to reach the sink, got %q", sink.buf.String())
+	}
+}
@@ -0,0 +1,148 @@
+package logger
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	traceparentHeader = "traceparent"
+	tracestateHeader  = "tracestate"
+)
+
+type spanContextKey struct{}
+
+// SpanContext carries the W3C trace context propagated through a single
+// request so that log lines and outgoing calls can be correlated to it.
+type SpanContext struct {
+	traceID    string
+	spanID     string
+	sampled    bool
+	tracestate string
+}
+
+func (sc SpanContext) TraceID() string { return sc.traceID }
+func (sc SpanContext) SpanID() string  { return sc.spanID }
+func (sc SpanContext) Sampled() bool   { return sc.sampled }
+
+// Traceparent renders sc as a W3C `traceparent` header value.
+func (sc SpanContext) Traceparent() string {
+	flags := "00"
+	if sc.sampled {
+		flags = "01"
+	}
+	return fmt.Sprintf("00-%s-%s-%s", sc.traceID, sc.spanID, flags)
+}
+
+// NewSpanContext returns a copy of ctx carrying sc.
+func NewSpanContext(ctx context.Context, sc SpanContext) context.Context {
+	return context.WithValue(ctx, spanContextKey{}, sc)
+}
+
+// SpanContextFromContext returns the SpanContext stored in ctx, if any.
+func SpanContextFromContext(ctx context.Context) (SpanContext, bool) {
+	sc, ok := ctx.Value(spanContextKey{}).(SpanContext)
+	return sc, ok
+}
+
+// InjectTraceparent sets the outgoing traceparent/tracestate headers on req
+// from the SpanContext carried in ctx. It is a no-op when ctx has none.
+func InjectTraceparent(ctx context.Context, req *http.Request) {
+	sc, ok := SpanContextFromContext(ctx)
+	if !ok {
+		return
+	}
+	req.Header.Set(traceparentHeader, sc.Traceparent())
+	if sc.tracestate != "" {
+		req.Header.Set(tracestateHeader, sc.tracestate)
+	}
+}
+
+// Sampler decides whether a trace identified by traceID should be sampled.
+type Sampler interface {
+	ShouldSample(traceID string) bool
+}
+
+type alwaysOnSampler struct{}
+
+func (alwaysOnSampler) ShouldSample(string) bool { return true }
+
+// AlwaysOn samples every trace.
+var AlwaysOn Sampler = alwaysOnSampler{}
+
+type alwaysOffSampler struct{}
+
+func (alwaysOffSampler) ShouldSample(string) bool { return false }
+
+// AlwaysOff never samples.
+var AlwaysOff Sampler = alwaysOffSampler{}
+
+type traceIDRatioSampler struct {
+	threshold uint64
+}
+
+// TraceIDRatio returns a Sampler that samples a deterministic fraction of
+// traces, decided from the low 8 bytes of the trace ID so that every service
+// in the path reaches the same decision for a given trace.
+func TraceIDRatio(fraction float64) Sampler {
+	if fraction <= 0 {
+		return AlwaysOff
+	}
+	if fraction >= 1 {
+		return AlwaysOn
+	}
+	return &traceIDRatioSampler{threshold: uint64(fraction * float64(math.MaxUint64))}
+}
+
+func (s *traceIDRatioSampler) ShouldSample(traceID string) bool {
+	if len(traceID) != 32 {
+		return false
+	}
+	low, err := strconv.ParseUint(traceID[16:], 16, 64)
+	if err != nil {
+		return false
+	}
+	return low <= s.threshold
+}
+
+// parseTraceparent validates header against the W3C
+// version-traceid-parentid-flags format, rejecting all-zero trace/span IDs,
+// non-hex segments, and wrong lengths.
+func parseTraceparent(header string) (traceID, spanID string, sampled bool, ok bool) {
+	parts := strings.Split(header, "-")
+	if len(parts) != 4 {
+		return "", "", false, false
+	}
+
+	version, traceID, spanID, flags := parts[0], parts[1], parts[2], parts[3]
+	if len(version) != 2 || len(traceID) != 32 || len(spanID) != 16 || len(flags) != 2 {
+		return "", "", false, false
+	}
+	if !isLowerHex(version) || !isLowerHex(traceID) || !isLowerHex(spanID) || !isLowerHex(flags) {
+		return "", "", false, false
+	}
+	if strings.Count(traceID, "0") == len(traceID) || strings.Count(spanID, "0") == len(spanID) {
+		return "", "", false, false
+	}
+
+	flagsByte, err := hex.DecodeString(flags)
+	if err != nil {
+		return "", "", false, false
+	}
+
+	return traceID, spanID, flagsByte[0]&0x01 == 1, true
+}
+
+func isLowerHex(s string) bool {
+	for _, r := range s {
+		if !(r >= '0' && r <= '9') && !(r >= 'a' && r <= 'f') {
+			return false
+		}
+	}
+	return true
+}
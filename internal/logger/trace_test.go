@@ -0,0 +1,158 @@
+package logger
+
+import "testing"
+
+func TestParseTraceparent(t *testing.T) {
+	const (
+		traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+		spanID  = "00f067aa0ba902b7"
+	)
+
+	tests := []struct {
+		name        string
+		header      string
+		wantTraceID string
+		wantSpanID  string
+		wantSampled bool
+		wantOK      bool
+	}{
+		{
+			name:        "valid sampled",
+			header:      "00-" + traceID + "-" + spanID + "-01",
+			wantTraceID: traceID,
+			wantSpanID:  spanID,
+			wantSampled: true,
+			wantOK:      true,
+		},
+		{
+			name:        "valid not sampled",
+			header:      "00-" + traceID + "-" + spanID + "-00",
+			wantTraceID: traceID,
+			wantSpanID:  spanID,
+			wantSampled: false,
+			wantOK:      true,
+		},
+		{
+			name:   "wrong segment count",
+			header: "00-" + traceID + "-" + spanID,
+			wantOK: false,
+		},
+		{
+			name:   "wrong trace id length",
+			header: "00-abcd-" + spanID + "-01",
+			wantOK: false,
+		},
+		{
+			name:   "non-hex trace id",
+			header: "00-" + "zzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzz" + "-" + spanID + "-01",
+			wantOK: false,
+		},
+		{
+			name:   "all-zero trace id rejected",
+			header: "00-00000000000000000000000000000000-" + spanID + "-01",
+			wantOK: false,
+		},
+		{
+			name:   "all-zero span id rejected",
+			header: "00-" + traceID + "-0000000000000000-01",
+			wantOK: false,
+		},
+		{
+			name:   "non-hex flags",
+			header: "00-" + traceID + "-" + spanID + "-zz",
+			wantOK: false,
+		},
+		{
+			name:   "empty header",
+			header: "",
+			wantOK: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotTraceID, gotSpanID, gotSampled, gotOK := parseTraceparent(tt.header)
+			if gotOK != tt.wantOK {
+				t.Fatalf("ok = %v, want %v", gotOK, tt.wantOK)
+			}
+			if !gotOK {
+				return
+			}
+			if gotTraceID != tt.wantTraceID {
+				t.Errorf("traceID = %q, want %q", gotTraceID, tt.wantTraceID)
+			}
+			if gotSpanID != tt.wantSpanID {
+				t.Errorf("spanID = %q, want %q", gotSpanID, tt.wantSpanID)
+			}
+			if gotSampled != tt.wantSampled {
+				t.Errorf("sampled = %v, want %v", gotSampled, tt.wantSampled)
+			}
+		})
+	}
+}
+
+func TestTraceIDRatio_Boundaries(t *testing.T) {
+	if TraceIDRatio(0).ShouldSample("ffffffffffffffffffffffffffffffff") {
+		t.Error("TraceIDRatio(0) sampled a trace; want it to behave like AlwaysOff")
+	}
+	if !TraceIDRatio(1).ShouldSample("ffffffffffffffffffffffffffffffff") {
+		t.Error("TraceIDRatio(1) did not sample a trace; want it to behave like AlwaysOn")
+	}
+}
+
+func TestTraceIDRatio_Deterministic(t *testing.T) {
+	sampler := TraceIDRatio(0.5)
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+
+	first := sampler.ShouldSample(traceID)
+	for i := 0; i < 10; i++ {
+		if got := sampler.ShouldSample(traceID); got != first {
+			t.Fatalf("ShouldSample(%q) is not deterministic: got %v, then %v", traceID, first, got)
+		}
+	}
+}
+
+func TestTraceIDRatio_ApproximatesFraction(t *testing.T) {
+	sampler := TraceIDRatio(0.25)
+
+	const n = 10000
+	sampled := 0
+	for i := 0; i < n; i++ {
+		traceID := randomHexTraceID(t, i)
+		if sampler.ShouldSample(traceID) {
+			sampled++
+		}
+	}
+
+	got := float64(sampled) / float64(n)
+	if got < 0.20 || got > 0.30 {
+		t.Fatalf("sampled fraction = %.3f, want close to 0.25", got)
+	}
+}
+
+func TestTraceIDRatio_InvalidTraceID(t *testing.T) {
+	sampler := TraceIDRatio(0.5)
+	if sampler.ShouldSample("not-a-valid-trace-id") {
+		t.Error("ShouldSample on a malformed trace ID should fail closed (false)")
+	}
+}
+
+// randomHexTraceID deterministically derives a distinct, valid-looking
+// 32-char hex trace ID from seed, so TestTraceIDRatio_ApproximatesFraction
+// doesn't depend on math/rand (disallowed by this module's verification
+// workflow) while still varying the low bytes the sampler keys off of.
+func randomHexTraceID(t *testing.T, seed int) string {
+	t.Helper()
+	const hexDigits = "0123456789abcdef"
+	x := uint64(seed)
+	b := make([]byte, 32)
+	for i := range b {
+		// splitmix64 step, so every seed yields its own digit sequence.
+		x += 0x9e3779b97f4a7c15
+		z := (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+		z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+		z ^= z >> 31
+		b[i] = hexDigits[z&0xf]
+	}
+	return string(b)
+}
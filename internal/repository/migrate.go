@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strings"
+)
+
+//go:embed migrations/sqlite/*.sql
+var sqliteMigrations embed.FS
+
+//go:embed migrations/postgres/*.sql
+var postgresMigrations embed.FS
+
+const createSchemaMigrations = `CREATE TABLE IF NOT EXISTS schema_migrations (
+	version TEXT PRIMARY KEY
+)`
+
+// RunMigrations applies every *.sql file embedded for dialect that is not
+// yet recorded in schema_migrations, in filename order. It is forward-only;
+// there is no down migration support.
+func RunMigrations(ctx context.Context, db *sql.DB, dialect Dialect) error {
+	if _, err := db.ExecContext(ctx, createSchemaMigrations); err != nil {
+		return fmt.Errorf("repository: creating schema_migrations: %w", err)
+	}
+
+	applied, err := appliedMigrations(ctx, db)
+	if err != nil {
+		return err
+	}
+
+	migrationsFS, dir := migrationsForDialect(dialect)
+	entries, err := fs.ReadDir(migrationsFS, dir)
+	if err != nil {
+		return fmt.Errorf("repository: reading migrations: %w", err)
+	}
+
+	var names []string
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasSuffix(e.Name(), ".sql") {
+			names = append(names, e.Name())
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		version := strings.TrimSuffix(name, ".sql")
+		if _, ok := applied[version]; ok {
+			continue
+		}
+
+		contents, err := fs.ReadFile(migrationsFS, dir+"/"+name)
+		if err != nil {
+			return fmt.Errorf("repository: reading migration %s: %w", name, err)
+		}
+		if _, err := db.ExecContext(ctx, string(contents)); err != nil {
+			return fmt.Errorf("repository: applying migration %s: %w", name, err)
+		}
+
+		insert := rebind(dialect, `INSERT INTO schema_migrations (version) VALUES (?)`)
+		if _, err := db.ExecContext(ctx, insert, version); err != nil {
+			return fmt.Errorf("repository: recording migration %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+func appliedMigrations(ctx context.Context, db *sql.DB) (map[string]struct{}, error) {
+	rows, err := db.QueryContext(ctx, `SELECT version FROM schema_migrations`)
+	if err != nil {
+		return nil, fmt.Errorf("repository: reading schema_migrations: %w", err)
+	}
+	defer rows.Close()
+
+	applied := make(map[string]struct{})
+	for rows.Next() {
+		var version string
+		if err := rows.Scan(&version); err != nil {
+			return nil, fmt.Errorf("repository: scanning schema_migrations: %w", err)
+		}
+		applied[version] = struct{}{}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository: iterating schema_migrations: %w", err)
+	}
+	return applied, nil
+}
+
+func migrationsForDialect(dialect Dialect) (fs.FS, string) {
+	if dialect == DialectPostgres {
+		return postgresMigrations, "migrations/postgres"
+	}
+	return sqliteMigrations, "migrations/sqlite"
+}
@@ -8,6 +8,7 @@ import (
 	"sync"
 	"task_api/internal/logger"
 	"task_api/internal/task"
+	"time"
 )
 
 var ErrNotFound = errors.New("task not found")
@@ -38,6 +39,7 @@ func (r *TaskRepository) Create(ctx context.Context, t *task.Task) (*task.Task,
 
 	r.counter++
 	t.ID = r.counter
+	t.CreatedAt = time.Now().UTC()
 
 	r.tasksByID[t.ID] = t
 	r.tasksByStatus[t.Status] = append(r.tasksByStatus[t.Status], t.ID)
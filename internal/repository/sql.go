@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"task_api/internal/logger"
+	"task_api/internal/task"
+	"task_api/internal/txmanager"
+	"time"
+)
+
+// Dialect selects the SQL variant a SQLTaskRepository speaks, since the
+// same queries need different placeholder and auto-increment syntax across
+// drivers.
+type Dialect string
+
+const (
+	DialectSQLite   Dialect = "sqlite"
+	DialectPostgres Dialect = "postgres"
+)
+
+const defaultQueryTimeout = 5 * time.Second
+
+// SQLTaskRepository satisfies task.Repository against database/sql. Run
+// RunMigrations against db before constructing it.
+type SQLTaskRepository struct {
+	db      *sql.DB
+	dialect Dialect
+	log     logger.Logger
+}
+
+func NewSQLTaskRepository(db *sql.DB, dialect Dialect, log logger.Logger) *SQLTaskRepository {
+	return &SQLTaskRepository{
+		db:      db,
+		dialect: dialect,
+		log:     log,
+	}
+}
+
+func (r *SQLTaskRepository) Create(ctx context.Context, t *task.Task) (*task.Task, error) {
+	log := logger.FromContext(ctx).With("where", "repository")
+	log.Debug("repository: creating task", "title", t.Title)
+
+	ctx, cancel := context.WithTimeout(ctx, defaultQueryTimeout)
+	defer cancel()
+
+	t.CreatedAt = time.Now().UTC()
+	q := txmanager.From(ctx, r.db)
+
+	if r.dialect == DialectPostgres {
+		query := r.rebind(`INSERT INTO tasks (title, status, created_at) VALUES (?, ?, ?) RETURNING id`)
+		if err := q.QueryRowContext(ctx, query, t.Title, t.Status, t.CreatedAt).Scan(&t.ID); err != nil {
+			return nil, fmt.Errorf("repository: error creating task: %w", err)
+		}
+		log.Debug("repository: task created successfully", "id", t.ID)
+		return t, nil
+	}
+
+	query := r.rebind(`INSERT INTO tasks (title, status, created_at) VALUES (?, ?, ?)`)
+	result, err := q.ExecContext(ctx, query, t.Title, t.Status, t.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("repository: error creating task: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("repository: error reading inserted task id: %w", err)
+	}
+	t.ID = id
+
+	log.Debug("repository: task created successfully", "id", t.ID)
+	return t, nil
+}
+
+func (r *SQLTaskRepository) GetByID(ctx context.Context, id string) (*task.Task, error) {
+	log := logger.FromContext(ctx).With("where", "repository")
+	log.Debug("repository: getting task by id", "id", id)
+
+	ctx, cancel := context.WithTimeout(ctx, defaultQueryTimeout)
+	defer cancel()
+
+	taskID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid task ID format: %w", err)
+	}
+
+	query := r.rebind(`SELECT id, title, status, created_at FROM tasks WHERE id = ?`)
+	q := txmanager.From(ctx, r.db)
+
+	var t task.Task
+	err = q.QueryRowContext(ctx, query, taskID).Scan(&t.ID, &t.Title, &t.Status, &t.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("repository: error getting task: %w", err)
+	}
+
+	log.Debug("repository: task retrieved from repository", "id", t.ID)
+	return &t, nil
+}
+
+func (r *SQLTaskRepository) GetAll(ctx context.Context, statusFilter string, limit, offset int) ([]*task.Task, error) {
+	log := logger.FromContext(ctx).With("where", "repository")
+	log.Debug("repository: getting all tasks", "status_filter", statusFilter, "limit", limit, "offset", offset)
+
+	ctx, cancel := context.WithTimeout(ctx, defaultQueryTimeout)
+	defer cancel()
+
+	var (
+		query string
+		args  []interface{}
+	)
+	if statusFilter != "" {
+		query = r.rebind(`SELECT id, title, status, created_at FROM tasks WHERE status = ? ORDER BY id LIMIT ? OFFSET ?`)
+		args = []interface{}{statusFilter, limit, offset}
+	} else {
+		query = r.rebind(`SELECT id, title, status, created_at FROM tasks ORDER BY id LIMIT ? OFFSET ?`)
+		args = []interface{}{limit, offset}
+	}
+
+	q := txmanager.From(ctx, r.db)
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("repository: error getting all tasks: %w", err)
+	}
+	defer rows.Close()
+
+	result := make([]*task.Task, 0, limit)
+	for rows.Next() {
+		var t task.Task
+		if err := rows.Scan(&t.ID, &t.Title, &t.Status, &t.CreatedAt); err != nil {
+			return nil, fmt.Errorf("repository: error scanning task: %w", err)
+		}
+		result = append(result, &t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository: error iterating tasks: %w", err)
+	}
+
+	log.Debug("repository: tasks retrieved from repository", "count", len(result))
+	return result, nil
+}
+
+// rebind rewrites a query written with `?` placeholders into the dialect's
+// native placeholder syntax ($1, $2, ... for Postgres).
+func (r *SQLTaskRepository) rebind(query string) string {
+	return rebind(r.dialect, query)
+}
+
+func rebind(dialect Dialect, query string) string {
+	if dialect != DialectPostgres {
+		return query
+	}
+
+	var sb strings.Builder
+	n := 0
+	for _, c := range query {
+		if c == '?' {
+			n++
+			sb.WriteString("$")
+			sb.WriteString(strconv.Itoa(n))
+			continue
+		}
+		sb.WriteRune(c)
+	}
+	return sb.String()
+}
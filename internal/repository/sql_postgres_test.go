@@ -0,0 +1,41 @@
+//go:build integration
+
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+
+	"task_api/internal/logger"
+
+	_ "github.com/lib/pq"
+)
+
+// TestSQLTaskRepository_Postgres exercises the Postgres dialect's rebind
+// ($N placeholders) and RETURNING id path against a real server. It only
+// runs under `go test -tags=integration`, and only when TEST_POSTGRES_DSN
+// points at a scratch database the test is free to migrate and write into.
+func TestSQLTaskRepository_Postgres(t *testing.T) {
+	dsn := os.Getenv("TEST_POSTGRES_DSN")
+	if dsn == "" {
+		t.Skip("TEST_POSTGRES_DSN not set, skipping Postgres integration test")
+	}
+
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("opening postgres database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	ctx := context.Background()
+	if err := RunMigrations(ctx, db, DialectPostgres); err != nil {
+		t.Fatalf("running migrations: %v", err)
+	}
+	t.Cleanup(func() {
+		db.ExecContext(ctx, `DROP TABLE IF EXISTS tasks, schema_migrations`)
+	})
+
+	exerciseTaskRepository(t, NewSQLTaskRepository(db, DialectPostgres, logger.NewNoOpLogger()))
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"task_api/internal/logger"
+	"task_api/internal/task"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func openSQLite(t *testing.T, dsn string) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite3", dsn)
+	if err != nil {
+		t.Fatalf("opening sqlite3 database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	if err := RunMigrations(context.Background(), db, DialectSQLite); err != nil {
+		t.Fatalf("running migrations: %v", err)
+	}
+	return db
+}
+
+func TestSQLTaskRepository_SQLite_Memory(t *testing.T) {
+	db := openSQLite(t, ":memory:")
+	exerciseTaskRepository(t, NewSQLTaskRepository(db, DialectSQLite, logger.NewNoOpLogger()))
+}
+
+func TestSQLTaskRepository_SQLite_File(t *testing.T) {
+	dsn := filepath.Join(t.TempDir(), "tasks.db")
+	db := openSQLite(t, dsn)
+	exerciseTaskRepository(t, NewSQLTaskRepository(db, DialectSQLite, logger.NewNoOpLogger()))
+}
+
+// exerciseTaskRepository drives the task.Repository contract against repo,
+// shared by every dialect's test so each one only has to provide a
+// migrated database.
+func exerciseTaskRepository(t *testing.T, repo task.Repository) {
+	t.Helper()
+	ctx := context.Background()
+
+	created, err := repo.Create(ctx, &task.Task{Title: "write tests", Status: "open"})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if created.ID == 0 {
+		t.Fatalf("Create: expected a generated ID, got 0")
+	}
+	if created.CreatedAt.IsZero() {
+		t.Fatalf("Create: expected CreatedAt to be set")
+	}
+
+	if _, err := repo.Create(ctx, &task.Task{Title: "ship it", Status: "closed"}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	got, err := repo.GetByID(ctx, strconv.FormatInt(created.ID, 10))
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if got.Title != "write tests" {
+		t.Errorf("GetByID: got title %q, want %q", got.Title, "write tests")
+	}
+
+	if _, err := repo.GetByID(ctx, "999999"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetByID: got err %v, want ErrNotFound", err)
+	}
+
+	all, err := repo.GetAll(ctx, "", 10, 0)
+	if err != nil {
+		t.Fatalf("GetAll: %v", err)
+	}
+	if len(all) != 2 {
+		t.Fatalf("GetAll: got %d tasks, want 2", len(all))
+	}
+
+	open, err := repo.GetAll(ctx, "open", 10, 0)
+	if err != nil {
+		t.Fatalf("GetAll with status filter: %v", err)
+	}
+	if len(open) != 1 || open[0].Title != "write tests" {
+		t.Fatalf("GetAll with status filter: got %+v, want just %q", open, "write tests")
+	}
+
+	paged, err := repo.GetAll(ctx, "", 1, 1)
+	if err != nil {
+		t.Fatalf("GetAll with limit/offset: %v", err)
+	}
+	if len(paged) != 1 {
+		t.Fatalf("GetAll with limit/offset: got %d tasks, want 1", len(paged))
+	}
+}
@@ -1,11 +1,15 @@
 package task
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 type Task struct {
-	ID     int64  `json:"id"`
-	Title  string `json:"title"`
-	Status string `json:"status"`
+	ID        int64     `json:"id"`
+	Title     string    `json:"title"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
 }
 type Repository interface {
 	Create(ctx context.Context, task *Task) (*Task, error)
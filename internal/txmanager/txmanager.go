@@ -0,0 +1,65 @@
+package txmanager
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+type key int
+
+const txKey key = iota
+
+// Manager begins and commits transactions against a *sql.DB, handing the
+// active transaction to callers through context so repository code can
+// participate in it transparently.
+type Manager struct {
+	db *sql.DB
+}
+
+func NewManager(db *sql.DB) *Manager {
+	return &Manager{db: db}
+}
+
+// WithTx runs fn inside a transaction: fn's ctx carries the transaction so
+// repository calls made with it run against the same connection. The
+// transaction commits if fn returns nil, and rolls back if fn returns an
+// error or panics.
+func (m *Manager) WithTx(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("txmanager: beginning transaction: %w", err)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	err = fn(context.WithValue(ctx, txKey, tx))
+	return err
+}
+
+// Querier is the subset of *sql.DB and *sql.Tx that repository code needs;
+// From returns whichever is active for ctx.
+type Querier interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// From returns the transaction carried in ctx by WithTx, falling back to
+// db when no transaction is in flight.
+func From(ctx context.Context, db *sql.DB) Querier {
+	if tx, ok := ctx.Value(txKey).(*sql.Tx); ok {
+		return tx
+	}
+	return db
+}